@@ -4,10 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+	"github.com/harikishoreadabala/go-retry-mechanism/retry/sqlretry"
 	_ "github.com/lib/pq"
 )
 
@@ -27,6 +27,7 @@ func NewOrderService(db *sql.DB) *OrderService {
 			MaxBackoff:     2 * time.Second,
 			BackoffFactor:  2.0,
 			JitterFactor:   0.1,
+			Classifiers:    []retry.Classifier{sqlretry.SQLClassifier()},
 		},
 	}
 }
@@ -36,9 +37,6 @@ func (s *OrderService) CreateOrder(ctx context.Context, customerID string, amoun
 	return retry.Do(ctx, s.retryConfig, func() error {
 		tx, err := s.db.BeginTx(ctx, nil)
 		if err != nil {
-			if isTransientDBError(err) {
-				return retry.RetryableError{Err: err}
-			}
 			return err
 		}
 		defer tx.Rollback()
@@ -46,22 +44,19 @@ func (s *OrderService) CreateOrder(ctx context.Context, customerID string, amoun
 		// Insert order
 		var orderID int
 		err = tx.QueryRowContext(ctx, `
-			INSERT INTO orders (customer_id, amount, status, created_at) 
-			VALUES ($1, $2, 'pending', NOW()) 
+			INSERT INTO orders (customer_id, amount, status, created_at)
+			VALUES ($1, $2, 'pending', NOW())
 			RETURNING id`,
 			customerID, amount,
 		).Scan(&orderID)
 
 		if err != nil {
-			if isTransientDBError(err) {
-				return retry.RetryableError{Err: err}
-			}
 			return err
 		}
 
 		// Update customer balance
 		_, err = tx.ExecContext(ctx, `
-			UPDATE customers 
+			UPDATE customers
 			SET balance = balance - $1,
 			    updated_at = NOW()
 			WHERE id = $2`,
@@ -69,9 +64,6 @@ func (s *OrderService) CreateOrder(ctx context.Context, customerID string, amoun
 		)
 
 		if err != nil {
-			if isTransientDBError(err) {
-				return retry.RetryableError{Err: err}
-			}
 			return err
 		}
 
@@ -79,34 +71,6 @@ func (s *OrderService) CreateOrder(ctx context.Context, customerID string, amoun
 	})
 }
 
-// isTransientDBError checks if database error is retryable
-func isTransientDBError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errStr := strings.ToLower(err.Error())
-
-	// Connection errors
-	if strings.Contains(errStr, "connection refused") ||
-		strings.Contains(errStr, "connection reset") ||
-		strings.Contains(errStr, "broken pipe") {
-		return true
-	}
-
-	// Deadlock
-	if strings.Contains(errStr, "deadlock") {
-		return true
-	}
-
-	// Timeout
-	if strings.Contains(errStr, "timeout") {
-		return true
-	}
-
-	return false
-}
-
 // Example with notification
 func (s *OrderService) CreateOrderWithLogging(ctx context.Context, customerID string, amount float64) error {
 	return retry.DoWithNotify(ctx, s.retryConfig, func() error {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/harikishoreadabala/go-retry-mechanism/retry"
@@ -64,9 +65,15 @@ func (c *RetryableHTTPClient) Do(ctx context.Context, req *http.Request) (*http.
 		if retry.IsRetryableHTTPStatus(resp.StatusCode) {
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
-			return retry.RetryableError{
-				Err: fmt.Errorf("retryable HTTP status %d: %s", resp.StatusCode, string(bodyBytes)),
+			retryErr := fmt.Errorf("retryable HTTP status %d: %s", resp.StatusCode, string(bodyBytes))
+
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+				if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+					return retry.RetryAfterError{Err: retryErr, After: after}
+				}
 			}
+
+			return retry.RetryableError{Err: retryErr}
 		}
 
 		return nil
@@ -75,6 +82,32 @@ func (c *RetryableHTTPClient) Do(ctx context.Context, req *http.Request) (*http.
 	return resp, err
 }
 
+// parseRetryAfter parses a Retry-After header value per RFC 7231, which
+// allows either delta-seconds ("120") or an HTTP-date
+// ("Fri, 31 Dec 1999 23:59:59 GMT").
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
 // Example usage
 func ExampleHTTPClient() {
 	client := NewRetryableHTTPClient()
@@ -0,0 +1,144 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy computes how long to wait before the next attempt.
+// Next receives the current attempt number (0-indexed) and the backoff
+// used for the previous attempt (0 on the first call), so stateful
+// strategies such as decorrelated jitter can build off it. Reset is called
+// whenever a caller starts a fresh retry loop, letting stateful strategies
+// drop anything they cached from a previous run.
+type BackoffStrategy interface {
+	Next(attempt int, lastBackoff time.Duration) time.Duration
+	Reset()
+}
+
+func computeExponentialBackoff(attempt int, initial, max time.Duration, factor, jitterFactor float64) time.Duration {
+	backoff := float64(initial) * math.Pow(factor, float64(attempt))
+
+	if backoff > float64(max) {
+		backoff = float64(max)
+	}
+
+	jitter := (rand.Float64() - 0.5) * jitterFactor * backoff
+
+	finalBackoff := backoff + jitter
+
+	if finalBackoff < 0 {
+		finalBackoff = 0
+	}
+
+	return time.Duration(finalBackoff)
+}
+
+type exponentialBackoff struct {
+	initial time.Duration
+	max     time.Duration
+	factor  float64
+	jitter  float64
+}
+
+// NewExponentialBackoff returns the package's default strategy: backoff
+// grows as initial*factor^attempt, capped at max, with up to jitterFactor of
+// symmetric jitter applied.
+func NewExponentialBackoff(initial, max time.Duration, factor, jitterFactor float64) BackoffStrategy {
+	return &exponentialBackoff{initial: initial, max: max, factor: factor, jitter: jitterFactor}
+}
+
+func (b *exponentialBackoff) Next(attempt int, _ time.Duration) time.Duration {
+	return computeExponentialBackoff(attempt, b.initial, b.max, b.factor, b.jitter)
+}
+
+func (b *exponentialBackoff) Reset() {}
+
+type linearBackoff struct {
+	waitBetween time.Duration
+}
+
+// NewLinearBackoff returns a strategy whose backoff grows by a fixed
+// increment each attempt: waitBetween * (attempt+1).
+func NewLinearBackoff(waitBetween time.Duration) BackoffStrategy {
+	return &linearBackoff{waitBetween: waitBetween}
+}
+
+func (b *linearBackoff) Next(attempt int, _ time.Duration) time.Duration {
+	return time.Duration(attempt+1) * b.waitBetween
+}
+
+func (b *linearBackoff) Reset() {}
+
+type constantBackoff struct {
+	wait time.Duration
+}
+
+// NewConstantBackoff returns a strategy that always waits the same duration.
+func NewConstantBackoff(wait time.Duration) BackoffStrategy {
+	return &constantBackoff{wait: wait}
+}
+
+func (b *constantBackoff) Next(int, time.Duration) time.Duration {
+	return b.wait
+}
+
+func (b *constantBackoff) Reset() {}
+
+type fullJitterBackoff struct {
+	base time.Duration
+	cap  time.Duration
+}
+
+// NewFullJitterBackoff returns the "full jitter" strategy popularized by the
+// AWS architecture blog: sleep = rand(0, min(cap, base*2^attempt)). It
+// spreads retries more aggressively than symmetric jitter, which helps avoid
+// thundering-herd retries against a recovering dependency.
+func NewFullJitterBackoff(base, cap time.Duration) BackoffStrategy {
+	return &fullJitterBackoff{base: base, cap: cap}
+}
+
+func (b *fullJitterBackoff) Next(attempt int, _ time.Duration) time.Duration {
+	upper := float64(b.base) * math.Pow(2, float64(attempt))
+	if upper > float64(b.cap) {
+		upper = float64(b.cap)
+	}
+	if upper <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Float64() * upper)
+}
+
+func (b *fullJitterBackoff) Reset() {}
+
+type decorrelatedJitterBackoff struct {
+	base time.Duration
+	cap  time.Duration
+}
+
+// NewDecorrelatedJitterBackoff returns the AWS "decorrelated jitter"
+// strategy: sleep = min(cap, rand(base, lastBackoff*3)). It tends to spread
+// retries more evenly than full jitter while still growing roughly
+// exponentially.
+func NewDecorrelatedJitterBackoff(base, cap time.Duration) BackoffStrategy {
+	return &decorrelatedJitterBackoff{base: base, cap: cap}
+}
+
+func (b *decorrelatedJitterBackoff) Next(_ int, lastBackoff time.Duration) time.Duration {
+	prev := lastBackoff
+	if prev <= 0 {
+		prev = b.base
+	}
+
+	upper := float64(prev) * 3
+	sleep := float64(b.base) + rand.Float64()*(upper-float64(b.base))
+	if sleep > float64(b.cap) {
+		sleep = float64(b.cap)
+	}
+
+	return time.Duration(sleep)
+}
+
+func (b *decorrelatedJitterBackoff) Reset() {}
@@ -0,0 +1,200 @@
+// Package breaker implements a three-state circuit breaker (Closed / Open /
+// HalfOpen) meant to sit in front of retry.Do so a chronically failing
+// dependency stops consuming retry budget once it's known to be down.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+// ErrCircuitOpen is returned by DoWithBreaker when the breaker is Open and
+// the operation was not invoked at all.
+var ErrCircuitOpen = errors.New("breaker: circuit open")
+
+// State is one of Closed, Open, or HalfOpen.
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config controls when a Breaker trips and how it recovers.
+type Config struct {
+	// FailureThreshold is the number of failures within Window that trips
+	// the breaker from Closed to Open.
+	FailureThreshold int
+
+	// Window is the rolling period over which failures are counted.
+	Window time.Duration
+
+	// Cooldown is how long the breaker stays Open before allowing a
+	// HalfOpen probe.
+	Cooldown time.Duration
+
+	// HalfOpenProbes is how many successful calls in a row are required
+	// while HalfOpen before the breaker closes again. Defaults to 1.
+	HalfOpenProbes int
+
+	// OnStateChange, if set, is called on every state transition so
+	// callers can log or instrument the breaker.
+	OnStateChange func(from, to State)
+}
+
+// Breaker is a three-state circuit breaker. The zero value is not usable;
+// construct one with New.
+type Breaker struct {
+	mu sync.Mutex
+
+	config Config
+	state  State
+
+	failureTimes []time.Time
+	openedAt     time.Time
+
+	halfOpenAttempts  int
+	halfOpenSuccesses int
+}
+
+// New creates a Breaker in the Closed state.
+func New(config Config) *Breaker {
+	if config.HalfOpenProbes <= 0 {
+		config.HalfOpenProbes = 1
+	}
+
+	return &Breaker{config: config, state: Closed}
+}
+
+// State reports the breaker's current state.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow reports whether a call may proceed right now, transitioning Open to
+// HalfOpen once the cooldown has elapsed and admitting up to HalfOpenProbes
+// concurrent probes while HalfOpen.
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Closed:
+		return true
+	case Open:
+		if time.Since(b.openedAt) < b.config.Cooldown {
+			return false
+		}
+		b.transition(HalfOpen)
+		b.halfOpenAttempts = 1
+		b.halfOpenSuccesses = 0
+		return true
+	case HalfOpen:
+		if b.halfOpenAttempts >= b.config.HalfOpenProbes {
+			return false
+		}
+		b.halfOpenAttempts++
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *Breaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case HalfOpen:
+		b.halfOpenSuccesses++
+		if b.halfOpenSuccesses >= b.config.HalfOpenProbes {
+			b.failureTimes = nil
+			b.transition(Closed)
+		}
+	case Closed:
+		b.failureTimes = nil
+	}
+}
+
+func (b *Breaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case HalfOpen:
+		b.trip()
+	case Closed:
+		now := time.Now()
+		b.failureTimes = append(b.failureTimes, now)
+		b.failureTimes = pruneBefore(b.failureTimes, now.Add(-b.config.Window))
+		if len(b.failureTimes) >= b.config.FailureThreshold {
+			b.trip()
+		}
+	}
+}
+
+func (b *Breaker) trip() {
+	b.openedAt = time.Now()
+	b.transition(Open)
+}
+
+func (b *Breaker) transition(to State) {
+	if b.state == to {
+		return
+	}
+
+	from := b.state
+	b.state = to
+	if b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
+
+func pruneBefore(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && times[i].Before(cutoff) {
+		i++
+	}
+	return times[i:]
+}
+
+// DoWithBreaker runs operation through retry.Do, consulting b before every
+// attempt. While b is Open, the operation is never invoked and no backoff
+// is slept -- Do fails fast with ErrCircuitOpen. A successful attempt while
+// HalfOpen counts toward closing the breaker again; a failure while
+// HalfOpen immediately re-opens it.
+func DoWithBreaker(ctx context.Context, config retry.Config, b *Breaker, operation retry.Retryable) error {
+	return retry.Do(ctx, config, func() error {
+		if !b.allow() {
+			return ErrCircuitOpen
+		}
+
+		if err := operation(); err != nil {
+			b.recordFailure()
+			return err
+		}
+
+		b.recordSuccess()
+		return nil
+	})
+}
@@ -0,0 +1,123 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+func TestBreakerTripsAfterThreshold(t *testing.T) {
+	b := New(Config{
+		FailureThreshold: 2,
+		Window:           time.Second,
+		Cooldown:         50 * time.Millisecond,
+	})
+
+	b.recordFailure()
+	if b.State() != Closed {
+		t.Fatalf("expected Closed after 1 failure, got %v", b.State())
+	}
+
+	b.recordFailure()
+	if b.State() != Open {
+		t.Fatalf("expected Open after 2 failures, got %v", b.State())
+	}
+}
+
+func TestBreakerHalfOpenAfterCooldown(t *testing.T) {
+	b := New(Config{
+		FailureThreshold: 1,
+		Window:           time.Second,
+		Cooldown:         10 * time.Millisecond,
+	})
+
+	b.recordFailure()
+	if b.State() != Open {
+		t.Fatalf("expected Open, got %v", b.State())
+	}
+
+	if b.allow() {
+		t.Fatal("expected allow() to refuse calls immediately after tripping")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("expected allow() to admit a probe after cooldown")
+	}
+	if b.State() != HalfOpen {
+		t.Fatalf("expected HalfOpen after cooldown, got %v", b.State())
+	}
+
+	b.recordSuccess()
+	if b.State() != Closed {
+		t.Fatalf("expected Closed after a successful probe, got %v", b.State())
+	}
+}
+
+func TestBreakerHalfOpenFailureReopens(t *testing.T) {
+	b := New(Config{
+		FailureThreshold: 1,
+		Window:           time.Second,
+		Cooldown:         10 * time.Millisecond,
+	})
+
+	b.recordFailure()
+	time.Sleep(20 * time.Millisecond)
+	b.allow()
+
+	b.recordFailure()
+	if b.State() != Open {
+		t.Fatalf("expected a failed probe to reopen the breaker, got %v", b.State())
+	}
+}
+
+func TestDoWithBreakerFailsFastWhenOpen(t *testing.T) {
+	b := New(Config{
+		FailureThreshold: 1,
+		Window:           time.Second,
+		Cooldown:         time.Minute,
+	})
+	b.recordFailure()
+
+	calls := 0
+	config := retry.Config{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		BackoffFactor:  1,
+	}
+
+	err := DoWithBreaker(context.Background(), config, b, func() error {
+		calls++
+		return nil
+	})
+
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected operation not to be invoked while breaker is open, got %d calls", calls)
+	}
+}
+
+func TestOnStateChangeHook(t *testing.T) {
+	var transitions []string
+	b := New(Config{
+		FailureThreshold: 1,
+		Window:           time.Second,
+		Cooldown:         time.Minute,
+		OnStateChange: func(from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	b.recordFailure()
+
+	if len(transitions) != 1 || transitions[0] != "closed->open" {
+		t.Fatalf("expected a single closed->open transition, got %v", transitions)
+	}
+}
@@ -0,0 +1,126 @@
+package retry
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// Decision is the result of classifying an error for retry purposes.
+type Decision int
+
+const (
+	// Unknown means the classifier has no opinion; evaluation moves on to
+	// the next classifier, and finally to the package defaults in
+	// IsRetryable.
+	Unknown Decision = iota
+	Retry
+	DoNotRetry
+)
+
+// Classifier decides whether an error should be retried. Config.Classifiers
+// runs in order before the IsRetryable defaults -- the first classifier to
+// return a Decision other than Unknown wins.
+type Classifier interface {
+	Classify(err error) Decision
+}
+
+// ClassifierFunc adapts a plain function to a Classifier.
+type ClassifierFunc func(err error) Decision
+
+func (f ClassifierFunc) Classify(err error) Decision {
+	return f(err)
+}
+
+// BackoffSuggester is optionally implemented alongside Classifier so a
+// classification can carry its own backoff recommendation (e.g. a parsed
+// Retry-After value), composing with the RetryAfterError handling in
+// nextBackoff.
+type BackoffSuggester interface {
+	SuggestBackoff(err error) (time.Duration, bool)
+}
+
+// classify runs config.Classifiers in order and falls back to IsRetryable
+// if none of them reach a decision.
+func classify(config Config, err error) bool {
+	for _, classifier := range config.Classifiers {
+		switch classifier.Classify(err) {
+		case Retry:
+			return true
+		case DoNotRetry:
+			return false
+		}
+	}
+
+	return IsRetryable(err)
+}
+
+// suggestedBackoff asks config.Classifiers, in order, whether any of them
+// suggest a backoff for err. It's consulted by nextBackoff after the
+// explicit RetryAfterError hint and before the configured BackoffStrategy.
+func suggestedBackoff(config Config, err error) (time.Duration, bool) {
+	for _, classifier := range config.Classifiers {
+		suggester, ok := classifier.(BackoffSuggester)
+		if !ok {
+			continue
+		}
+		if after, ok := suggester.SuggestBackoff(err); ok {
+			return after, true
+		}
+	}
+
+	return 0, false
+}
+
+// HTTPStatusError lets an operation report the HTTP status code it
+// observed, so HTTPStatusClassifier can classify it without parsing error
+// strings.
+type HTTPStatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e HTTPStatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e HTTPStatusError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusClassifier retries errors whose HTTPStatusError.StatusCode is in
+// retryable, and refuses to retry any other HTTPStatusError. It has no
+// opinion on errors that aren't an HTTPStatusError.
+func HTTPStatusClassifier(retryable ...int) Classifier {
+	return ClassifierFunc(func(err error) Decision {
+		var statusErr HTTPStatusError
+		if !errors.As(err, &statusErr) {
+			return Unknown
+		}
+
+		for _, code := range retryable {
+			if statusErr.StatusCode == code {
+				return Retry
+			}
+		}
+
+		return DoNotRetry
+	})
+}
+
+// RegexpClassifier retries errors whose message matches pattern. It's meant
+// for drivers that only expose transient failures as unstructured strings
+// (e.g. "connection reset by peer").
+func RegexpClassifier(pattern string) Classifier {
+	re := regexp.MustCompile(pattern)
+
+	return ClassifierFunc(func(err error) Decision {
+		if err == nil {
+			return Unknown
+		}
+		if re.MatchString(err.Error()) {
+			return Retry
+		}
+		return Unknown
+	})
+}
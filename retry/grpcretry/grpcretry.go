@@ -0,0 +1,232 @@
+// Package grpcretry adapts retry.Do into gRPC client interceptors, in the
+// same spirit as the go-grpc-middleware retry interceptor: retry decisions
+// are driven by gRPC status codes rather than arbitrary errors, and callers
+// can override behavior per-call via grpc.CallOption-style options.
+package grpcretry
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+// defaultRetriableCodes mirrors the codes go-grpc-middleware retries by
+// default: transient unavailability, resource exhaustion, lock-step
+// aborts, and deadline overruns that are often just slow retries in
+// disguise.
+var defaultRetriableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.ResourceExhausted,
+	codes.Aborted,
+	codes.DeadlineExceeded,
+}
+
+// CallOption is a real grpc.CallOption (via the embedded EmptyCallOption
+// no-op before/after hooks), so it can be passed either to
+// UnaryClientInterceptor/StreamClientInterceptor when building the
+// interceptor, or alongside an individual RPC call
+// (e.g. client.Method(ctx, req, grpcretry.WithMax(5))) to override that one
+// call. The interceptor pulls its own CallOptions back out of the
+// grpc.CallOption slice it receives per call; see filterCallOptions.
+type CallOption struct {
+	grpc.EmptyCallOption
+	apply func(*callOptions)
+}
+
+type callOptions struct {
+	max             int
+	backoff         retry.BackoffStrategy
+	perRetryTimeout time.Duration
+	codes           []codes.Code
+}
+
+// WithMax overrides Config.MaxRetries.
+func WithMax(maxRetries int) CallOption {
+	return CallOption{apply: func(o *callOptions) { o.max = maxRetries }}
+}
+
+// WithBackoff overrides Config.Strategy.
+func WithBackoff(strategy retry.BackoffStrategy) CallOption {
+	return CallOption{apply: func(o *callOptions) { o.backoff = strategy }}
+}
+
+// WithPerRetryTimeout bounds each individual attempt with its own context
+// timeout, independent of the overall call deadline.
+func WithPerRetryTimeout(timeout time.Duration) CallOption {
+	return CallOption{apply: func(o *callOptions) { o.perRetryTimeout = timeout }}
+}
+
+// WithCodes overrides which gRPC status codes are considered retriable.
+func WithCodes(retriableCodes ...codes.Code) CallOption {
+	return CallOption{apply: func(o *callOptions) { o.codes = retriableCodes }}
+}
+
+// WithRetriableCodes is an alias for WithCodes matching the terminology used
+// by callers configuring the interceptor up front.
+func WithRetriableCodes(retriableCodes ...codes.Code) CallOption {
+	return WithCodes(retriableCodes...)
+}
+
+// resolveOptions builds the interceptor-wide defaults from the options
+// passed to UnaryClientInterceptor/StreamClientInterceptor at construction
+// time. Per-call options layer on top of this via applyCallOptions.
+func resolveOptions(config retry.Config, opts []CallOption) callOptions {
+	o := callOptions{
+		max:   config.MaxRetries,
+		codes: defaultRetriableCodes,
+	}
+
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	return o
+}
+
+// applyCallOptions layers per-call overrides on top of the interceptor's
+// base options without mutating base.
+func applyCallOptions(base callOptions, perCall []CallOption) callOptions {
+	o := base
+	for _, opt := range perCall {
+		opt.apply(&o)
+	}
+	return o
+}
+
+// filterCallOptions splits the grpc.CallOption slice a real RPC call
+// receives into grpcretry's own CallOptions (consumed here) and the rest
+// (forwarded to invoker/streamer unchanged).
+func filterCallOptions(callOpts []grpc.CallOption) (ours []CallOption, rest []grpc.CallOption) {
+	for _, opt := range callOpts {
+		if o, ok := opt.(CallOption); ok {
+			ours = append(ours, o)
+			continue
+		}
+		rest = append(rest, opt)
+	}
+	return ours, rest
+}
+
+func (o callOptions) retryConfig(base retry.Config) retry.Config {
+	cfg := base
+	cfg.MaxRetries = o.max
+	if o.backoff != nil {
+		cfg.Strategy = o.backoff
+	}
+	return cfg
+}
+
+// GRPCCodeClassifier returns a retry.Classifier that retries errors whose
+// gRPC status code is in retriableCodes, and refuses to retry any other
+// gRPC status error. It's meant for Config.Classifiers on calls made
+// outside of UnaryClientInterceptor/StreamClientInterceptor, which already
+// classify by code internally.
+func GRPCCodeClassifier(retriableCodes ...codes.Code) retry.Classifier {
+	return retry.ClassifierFunc(func(err error) retry.Decision {
+		if _, ok := status.FromError(err); !ok {
+			return retry.Unknown
+		}
+
+		if isRetriableStatus(err, retriableCodes) {
+			return retry.Retry
+		}
+
+		return retry.DoNotRetry
+	})
+}
+
+func isRetriableStatus(err error, retriableCodes []codes.Code) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	for _, code := range retriableCodes {
+		if st.Code() == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withPerRetryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor that retries
+// failed unary calls via retry.Do, classifying failures by gRPC status code.
+// Any of WithMax/WithBackoff/WithPerRetryTimeout/WithCodes passed to opts
+// set the defaults for every call through this interceptor; the same
+// options passed as grpc.CallOption on an individual invocation override
+// those defaults for just that call.
+func UnaryClientInterceptor(config retry.Config, opts ...CallOption) grpc.UnaryClientInterceptor {
+	base := resolveOptions(config, opts)
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		perCall, rest := filterCallOptions(callOpts)
+		o := applyCallOptions(base, perCall)
+		retryConfig := o.retryConfig(config)
+
+		return retry.Do(ctx, retryConfig, func() error {
+			callCtx, cancel := withPerRetryTimeout(ctx, o.perRetryTimeout)
+			defer cancel()
+
+			err := invoker(callCtx, method, req, reply, cc, rest...)
+			if err == nil {
+				return nil
+			}
+
+			if !isRetriableStatus(err, o.codes) {
+				return err
+			}
+
+			return retry.RetryableError{Err: err}
+		})
+	}
+}
+
+// StreamClientInterceptor returns a grpc.StreamClientInterceptor that
+// retries stream establishment via retry.Do. Per the go-grpc-middleware
+// retry contract, only the initial streamer() call is retried -- once
+// streamer() has returned a stream successfully, retry.Do has already
+// finished, so a later RecvMsg failure on that stream is returned to the
+// caller as-is rather than triggering a fresh streamer() call underneath
+// them, which would silently drop any message they'd already seen.
+func StreamClientInterceptor(config retry.Config, opts ...CallOption) grpc.StreamClientInterceptor {
+	base := resolveOptions(config, opts)
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		perCall, rest := filterCallOptions(callOpts)
+		o := applyCallOptions(base, perCall)
+		retryConfig := o.retryConfig(config)
+
+		var stream grpc.ClientStream
+
+		err := retry.Do(ctx, retryConfig, func() error {
+			callCtx, cancel := withPerRetryTimeout(ctx, o.perRetryTimeout)
+			defer cancel()
+
+			s, err := streamer(callCtx, desc, cc, method, rest...)
+			if err != nil {
+				if !isRetriableStatus(err, o.codes) {
+					return err
+				}
+				return retry.RetryableError{Err: err}
+			}
+
+			stream = s
+			return nil
+		})
+
+		return stream, err
+	}
+}
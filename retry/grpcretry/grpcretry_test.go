@@ -0,0 +1,204 @@
+package grpcretry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+func TestIsRetriableStatus(t *testing.T) {
+	if !isRetriableStatus(status.Error(codes.Unavailable, "down"), defaultRetriableCodes) {
+		t.Fatal("expected Unavailable to be retriable")
+	}
+
+	if isRetriableStatus(status.Error(codes.InvalidArgument, "bad"), defaultRetriableCodes) {
+		t.Fatal("expected InvalidArgument to not be retriable")
+	}
+
+	if isRetriableStatus(errors.New("plain error"), defaultRetriableCodes) {
+		t.Fatal("expected a non-status error to not be retriable")
+	}
+}
+
+func TestGRPCCodeClassifier(t *testing.T) {
+	classifier := GRPCCodeClassifier(codes.Unavailable)
+
+	if classifier.Classify(status.Error(codes.Unavailable, "down")) != retry.Retry {
+		t.Fatal("expected Unavailable to classify as Retry")
+	}
+	if classifier.Classify(status.Error(codes.InvalidArgument, "bad")) != retry.DoNotRetry {
+		t.Fatal("expected InvalidArgument to classify as DoNotRetry")
+	}
+	if classifier.Classify(errors.New("plain error")) != retry.Unknown {
+		t.Fatal("expected a non-status error to classify as Unknown")
+	}
+}
+
+func testConfig() retry.Config {
+	return retry.Config{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		BackoffFactor:  1,
+		JitterFactor:   0,
+	}
+}
+
+func TestUnaryClientInterceptorRetriesRetriableCodes(t *testing.T) {
+	attempts := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		if attempts < 3 {
+			return status.Error(codes.Unavailable, "down")
+		}
+		return nil
+	}
+
+	interceptor := UnaryClientInterceptor(testConfig())
+
+	err := interceptor(context.Background(), "/svc/Method", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestUnaryClientInterceptorDoesNotRetryNonRetriableCodes(t *testing.T) {
+	attempts := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.InvalidArgument, "bad")
+	}
+
+	interceptor := UnaryClientInterceptor(testConfig())
+
+	if err := interceptor(context.Background(), "/svc/Method", nil, nil, nil, invoker); err == nil {
+		t.Fatal("expected failure")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected no retries for a non-retriable code, got %d attempts", attempts)
+	}
+}
+
+func TestUnaryClientInterceptorPerCallOverride(t *testing.T) {
+	attempts := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.Unavailable, "down")
+	}
+
+	// Interceptor-wide default allows 5 attempts; the per-call WithMax(2)
+	// must override it for just this invocation.
+	interceptor := UnaryClientInterceptor(retry.Config{
+		MaxRetries:     5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		BackoffFactor:  1,
+	})
+
+	err := interceptor(context.Background(), "/svc/Method", nil, nil, nil, invoker, WithMax(2))
+	if err == nil {
+		t.Fatal("expected failure")
+	}
+	if attempts != 2 {
+		t.Fatalf("expected per-call WithMax(2) to cap attempts at 2, got %d", attempts)
+	}
+}
+
+func TestWithPerRetryTimeoutDoesNotCancelParentContext(t *testing.T) {
+	attempts := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		<-ctx.Done()
+		if attempts < 2 {
+			return status.Error(codes.Unavailable, "down")
+		}
+		return nil
+	}
+
+	parentCtx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	interceptor := UnaryClientInterceptor(testConfig(), WithPerRetryTimeout(5*time.Millisecond))
+
+	if err := interceptor(parentCtx, "/svc/Method", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+	if parentCtx.Err() != nil {
+		t.Fatal("expected the per-retry timeout not to cancel the parent context")
+	}
+}
+
+type fakeClientStream struct {
+	recvErr   error
+	recvCalls int
+}
+
+func (s *fakeClientStream) Header() (metadata.MD, error) { return nil, nil }
+func (s *fakeClientStream) Trailer() metadata.MD         { return nil }
+func (s *fakeClientStream) CloseSend() error             { return nil }
+func (s *fakeClientStream) Context() context.Context     { return context.Background() }
+func (s *fakeClientStream) SendMsg(m interface{}) error  { return nil }
+func (s *fakeClientStream) RecvMsg(m interface{}) error {
+	s.recvCalls++
+	return s.recvErr
+}
+
+func TestStreamClientInterceptorRetriesEstablishment(t *testing.T) {
+	streamerCalls := 0
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamerCalls++
+		if streamerCalls < 2 {
+			return nil, status.Error(codes.Unavailable, "down")
+		}
+		return &fakeClientStream{}, nil
+	}
+
+	interceptor := StreamClientInterceptor(testConfig())
+
+	if _, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Method", streamer); err != nil {
+		t.Fatalf("expected stream establishment to succeed after retry, got %v", err)
+	}
+	if streamerCalls != 2 {
+		t.Fatalf("expected streamer retried once before success, got %d calls", streamerCalls)
+	}
+}
+
+func TestStreamClientInterceptorDoesNotRetryAfterMessageReceived(t *testing.T) {
+	streamerCalls := 0
+	fake := &fakeClientStream{recvErr: errors.New("mid-stream failure")}
+
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamerCalls++
+		return fake, nil
+	}
+
+	interceptor := StreamClientInterceptor(testConfig())
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Method", streamer)
+	if err != nil {
+		t.Fatalf("expected stream establishment to succeed, got %v", err)
+	}
+
+	var reply struct{}
+	if recvErr := stream.RecvMsg(&reply); recvErr == nil {
+		t.Fatal("expected RecvMsg to surface the underlying failure")
+	}
+
+	if streamerCalls != 1 {
+		t.Fatalf("expected streamer invoked exactly once (no retry after RecvMsg failure), got %d calls", streamerCalls)
+	}
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+// SpanHooks returns a retry.Hooks that annotates the span active in ctx
+// with one event per retry attempt, so a trace shows exactly when and why
+// an operation was retried without needing separate log correlation.
+func SpanHooks(ctx context.Context) retry.Hooks {
+	span := trace.SpanFromContext(ctx)
+
+	return retry.Hooks{
+		OnRetry: func(attempt int, backoff time.Duration, err error) {
+			span.AddEvent("retry.attempt", trace.WithAttributes(
+				attribute.Int("retry.attempt", attempt),
+				attribute.String("retry.backoff", backoff.String()),
+				attribute.String("retry.error", err.Error()),
+			))
+		},
+		OnGiveUp: func(err error) {
+			span.AddEvent("retry.give_up", trace.WithAttributes(
+				attribute.String("retry.error", err.Error()),
+			))
+		},
+		OnSuccess: func(attempts int, elapsed time.Duration) {
+			span.AddEvent("retry.success", trace.WithAttributes(
+				attribute.Int("retry.attempts", attempts),
+				attribute.String("retry.elapsed", elapsed.String()),
+			))
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanHooksRecordEvents(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "operation")
+	hooks := SpanHooks(ctx)
+
+	hooks.OnRetry(0, 10*time.Millisecond, errors.New("boom"))
+	hooks.OnGiveUp(errors.New("final failure"))
+	hooks.OnSuccess(3, 100*time.Millisecond)
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 ended span, got %d", len(spans))
+	}
+
+	events := spans[0].Events()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 span events, got %d", len(events))
+	}
+
+	names := []string{events[0].Name, events[1].Name, events[2].Name}
+	expected := []string{"retry.attempt", "retry.give_up", "retry.success"}
+	for i, name := range names {
+		if name != expected[i] {
+			t.Fatalf("event %d: expected name %q, got %q", i, expected[i], name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package metrics binds retry.Hooks to Prometheus and OpenTelemetry, so
+// libraries using retry.Do can expose attempt counts, backoff duration, and
+// outcomes without printing to stdout.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+// Collector holds the Prometheus vectors a retry.Hooks reports into.
+type Collector struct {
+	Attempts        *prometheus.CounterVec
+	BackoffDuration prometheus.Histogram
+	Outcomes        *prometheus.CounterVec
+}
+
+// NewCollector creates a Collector with vectors under the given namespace.
+// Call MustRegister to expose them on a registry.
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		Attempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retry_attempts_total",
+			Help:      "Number of retry attempts made, labeled by per-attempt outcome.",
+		}, []string{"outcome"}),
+		BackoffDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "retry_backoff_seconds",
+			Help:      "Backoff duration slept between retry attempts.",
+		}),
+		Outcomes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retry_outcomes_total",
+			Help:      "Final outcome of a retry loop, labeled by error class.",
+		}, []string{"error_class"}),
+	}
+}
+
+// MustRegister registers all of the collector's vectors on registry.
+func (c *Collector) MustRegister(registry prometheus.Registerer) {
+	registry.MustRegister(c.Attempts, c.BackoffDuration, c.Outcomes)
+}
+
+// Hooks returns a retry.Hooks that records attempts, backoff, and the final
+// outcome into c. Pass it as Config.Hooks.
+func (c *Collector) Hooks() retry.Hooks {
+	return retry.Hooks{
+		OnAttempt: func(_ int, err error) {
+			if err == nil {
+				c.Attempts.WithLabelValues("success").Inc()
+			} else {
+				c.Attempts.WithLabelValues("failure").Inc()
+			}
+		},
+		OnRetry: func(_ int, backoff time.Duration, _ error) {
+			c.BackoffDuration.Observe(backoff.Seconds())
+		},
+		OnGiveUp: func(err error) {
+			c.Outcomes.WithLabelValues(errorClass(err)).Inc()
+		},
+		OnSuccess: func(int, time.Duration) {
+			c.Outcomes.WithLabelValues("success").Inc()
+		},
+	}
+}
+
+// errorClass buckets a final retry error into a low-cardinality label value
+// suitable for a Prometheus metric.
+func errorClass(err error) string {
+	switch {
+	case errors.Is(err, retry.ErrMaxElapsed):
+		return "max_elapsed"
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return "context"
+	default:
+		return "exhausted"
+	}
+}
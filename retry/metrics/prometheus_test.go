@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+func TestCollectorHooksRecordAttemptsAndOutcomes(t *testing.T) {
+	c := NewCollector("test")
+	hooks := c.Hooks()
+
+	hooks.OnAttempt(0, errors.New("boom"))
+	hooks.OnAttempt(1, nil)
+
+	if got := testutil.ToFloat64(c.Attempts.WithLabelValues("failure")); got != 1 {
+		t.Fatalf("expected 1 failed attempt recorded, got %v", got)
+	}
+	if got := testutil.ToFloat64(c.Attempts.WithLabelValues("success")); got != 1 {
+		t.Fatalf("expected 1 successful attempt recorded, got %v", got)
+	}
+
+	hooks.OnSuccess(2, 0)
+	if got := testutil.ToFloat64(c.Outcomes.WithLabelValues("success")); got != 1 {
+		t.Fatalf("expected 1 success outcome recorded, got %v", got)
+	}
+
+	hooks.OnGiveUp(retry.ErrMaxElapsed)
+	if got := testutil.ToFloat64(c.Outcomes.WithLabelValues("max_elapsed")); got != 1 {
+		t.Fatalf("expected 1 max_elapsed outcome recorded, got %v", got)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"max elapsed", retry.ErrMaxElapsed, "max_elapsed"},
+		{"wrapped max elapsed", errWrap(retry.ErrMaxElapsed), "max_elapsed"},
+		{"context deadline exceeded", context.DeadlineExceeded, "context"},
+		{"context canceled", context.Canceled, "context"},
+		{"not retryable, not context, not max elapsed", errors.New("not retryable error: boom"), "exhausted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorClass(tt.err); got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestErrorClassThroughCancelledDo drives a real retry.Do call that's
+// cancelled mid-loop, rather than calling errorClass with a synthetic
+// context.Canceled -- retry.go builds the give-up error by wrapping both
+// ctx.Err() and the operation's last error, and it's that wrapping this
+// test guards, not errorClass's bucketing logic in isolation.
+func TestErrorClassThroughCancelledDo(t *testing.T) {
+	c := NewCollector("test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	operation := func() error {
+		attempts++
+		if attempts == 1 {
+			cancel()
+		}
+		return retry.RetryableError{Err: errors.New("boom")}
+	}
+
+	config := retry.Config{
+		MaxRetries:     5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		BackoffFactor:  1,
+		Hooks:          c.Hooks(),
+	}
+
+	err := retry.Do(ctx, config, operation)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Do's give-up error to wrap context.Canceled, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(c.Outcomes.WithLabelValues("context")); got != 1 {
+		t.Fatalf("expected 1 context outcome recorded via OnGiveUp, got %v", got)
+	}
+}
+
+func errWrap(err error) error {
+	return &wrappedErr{err}
+}
+
+type wrappedErr struct{ err error }
+
+func (w *wrappedErr) Error() string { return "wrapped: " + w.err.Error() }
+func (w *wrappedErr) Unwrap() error { return w.err }
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
-	"math/rand"
 	"net"
 	"net/http"
 	"syscall"
@@ -13,11 +11,85 @@ import (
 )
 
 type Config struct {
+	// MaxRetries caps the number of attempts. A value of 0 means unlimited
+	// attempts, bounded only by ctx or MaxElapsedTime -- the "retry forever
+	// until the caller gives up" pattern used by long-lived reconnect loops.
 	MaxRetries     int
 	InitialBackoff time.Duration
 	MaxBackoff     time.Duration
 	BackoffFactor  float64
 	JitterFactor   float64
+
+	// Strategy overrides how backoff is computed between attempts. When nil,
+	// Do and DoWithNotify fall back to the exponential-with-jitter behavior
+	// driven by InitialBackoff/MaxBackoff/BackoffFactor/JitterFactor above.
+	Strategy BackoffStrategy
+
+	// MaxElapsedTime bounds retries by wall-clock time instead of attempt
+	// count. Once the time spent since the first attempt would exceed this
+	// budget, Do and DoWithNotify give up rather than sleeping further.
+	// 0 means unlimited.
+	MaxElapsedTime time.Duration
+
+	// Hooks lets callers observe retry progress without depending on
+	// stdout. Any of its fields may be left nil.
+	Hooks Hooks
+
+	// Classifiers are consulted in order to decide whether an error is
+	// retryable, before falling back to IsRetryable's defaults. The first
+	// classifier to reach a Decision other than Unknown wins.
+	Classifiers []Classifier
+}
+
+// Hooks are observability callbacks invoked at each stage of a retry loop.
+// They're the seam the retry/metrics subpackage binds to; set them directly
+// for simple logging or tracing needs.
+type Hooks struct {
+	// OnAttempt is called after every attempt, including the final
+	// successful one, with the error returned (nil on success).
+	OnAttempt func(attempt int, err error)
+
+	// OnRetry is called when an attempt failed and a retry is about to be
+	// scheduled, once the backoff for that retry has been computed.
+	OnRetry func(attempt int, backoff time.Duration, err error)
+
+	// OnGiveUp is called exactly once, right before Do/DoWithNotify
+	// returns a final error.
+	OnGiveUp func(err error)
+
+	// OnSuccess is called exactly once, right before Do/DoWithNotify
+	// returns nil.
+	OnSuccess func(attempts int, totalElapsed time.Duration)
+}
+
+// ErrMaxElapsed is returned (wrapped around the last operation error) when
+// Config.MaxElapsedTime is exceeded before the operation succeeds.
+var ErrMaxElapsed = errors.New("retry: max elapsed time exceeded")
+
+// attemptsRemain reports whether another attempt is allowed under
+// MaxRetries, treating 0 as unlimited.
+func attemptsRemain(attempt, maxRetries int) bool {
+	return maxRetries == 0 || attempt < maxRetries
+}
+
+// elapsedExceeded reports whether sleeping for backoff would push the total
+// retry time past MaxElapsedTime. A zero MaxElapsedTime means unlimited.
+func elapsedExceeded(config Config, start time.Time, backoff time.Duration) bool {
+	if config.MaxElapsedTime <= 0 {
+		return false
+	}
+
+	return time.Since(start)+backoff > config.MaxElapsedTime
+}
+
+// resolveStrategy returns config.Strategy, or the legacy exponential
+// backoff built from config's numeric fields if none was set.
+func resolveStrategy(config Config) BackoffStrategy {
+	if config.Strategy != nil {
+		return config.Strategy
+	}
+
+	return NewExponentialBackoff(config.InitialBackoff, config.MaxBackoff, config.BackoffFactor, config.JitterFactor)
 }
 
 func DefaultConfig() Config {
@@ -44,86 +116,116 @@ func (e RetryableError) Unwrap() error {
 	return e.Err
 }
 
+// RetryAfterError lets an operation communicate a server-provided backoff
+// hint (e.g. an HTTP Retry-After header or a gRPC RetryInfo) back to Do. When
+// an operation returns a RetryAfterError, the requested duration overrides
+// the computed backoff for that attempt, clamped to Config.MaxBackoff.
+type RetryAfterError struct {
+	Err   error
+	After time.Duration
+}
+
+func (e RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
 func Do(ctx context.Context, config Config, operation Retryable) error {
 	var lastErr error
+	strategy := resolveStrategy(config)
+	strategy.Reset()
+	var lastBackoff time.Duration
+	start := time.Now()
 
-	for attempt := 0; attempt < config.MaxRetries; attempt++ {
+	for attempt := 0; attemptsRemain(attempt, config.MaxRetries); attempt++ {
 		lastErr = operation()
+
+		if config.Hooks.OnAttempt != nil {
+			config.Hooks.OnAttempt(attempt, lastErr)
+		}
+
 		if lastErr == nil {
+			if config.Hooks.OnSuccess != nil {
+				config.Hooks.OnSuccess(attempt+1, time.Since(start))
+			}
 			return nil
 		}
 
-		fmt.Printf("Attempt %d/%d: %v\n", attempt, config.MaxRetries, lastErr)
-
-		if !IsRetryable(lastErr) {
-			return fmt.Errorf("not retryable error: %w", lastErr)
+		if !classify(config, lastErr) {
+			return giveUp(config, fmt.Errorf("not retryable error: %w", lastErr))
 		}
 
-		if attempt == config.MaxRetries {
-			break
+		backOff := nextBackoff(attempt, config, lastErr, strategy, lastBackoff)
+		lastBackoff = backOff
+
+		if elapsedExceeded(config, start, backOff) {
+			return giveUp(config, fmt.Errorf("%w: %v", ErrMaxElapsed, lastErr))
 		}
 
-		backOff := calculateBackoff(attempt, config)
+		if config.Hooks.OnRetry != nil {
+			config.Hooks.OnRetry(attempt, backOff, lastErr)
+		}
 
 		select {
 		case <-time.After(backOff):
 		case <-ctx.Done():
-			return fmt.Errorf("timed out: %w", lastErr)
+			return giveUp(config, fmt.Errorf("timed out: %w: %w", ctx.Err(), lastErr))
 		}
 
 	}
-	return fmt.Errorf("retries exceeded: %w", lastErr)
+	return giveUp(config, fmt.Errorf("retries exceeded: %w", lastErr))
 }
 
-func calculateBackoff(attempt int, config Config) time.Duration {
-
-	backoff := float64(config.InitialBackoff) * math.Pow(config.BackoffFactor, float64(attempt))
-
-	if backoff > float64(config.MaxBackoff) {
-		backoff = float64(config.MaxBackoff)
+// giveUp reports err through Hooks.OnGiveUp, if set, and returns it
+// unchanged so callers can use it directly as a return statement.
+func giveUp(config Config, err error) error {
+	if config.Hooks.OnGiveUp != nil {
+		config.Hooks.OnGiveUp(err)
 	}
+	return err
+}
 
-	jitter := (rand.Float64() - 0.5) * config.JitterFactor * backoff
+func calculateBackoff(attempt int, config Config) time.Duration {
+	return computeExponentialBackoff(attempt, config.InitialBackoff, config.MaxBackoff, config.BackoffFactor, config.JitterFactor)
+}
 
-	finalBackoff := backoff + jitter
+// nextBackoff computes the backoff for attempt. An explicit RetryAfterError
+// hint on lastErr wins first, then any Classifier-suggested backoff, and
+// only then the configured BackoffStrategy. Hints are clamped to MaxBackoff.
+func nextBackoff(attempt int, config Config, lastErr error, strategy BackoffStrategy, lastBackoff time.Duration) time.Duration {
+	var retryAfter RetryAfterError
+	if errors.As(lastErr, &retryAfter) {
+		return clampBackoff(config, retryAfter.After)
+	}
 
-	if finalBackoff < 0 {
-		finalBackoff = 0
+	if after, ok := suggestedBackoff(config, lastErr); ok {
+		return clampBackoff(config, after)
 	}
 
-	return time.Duration(finalBackoff)
+	return strategy.Next(attempt, lastBackoff)
+}
 
+func clampBackoff(config Config, d time.Duration) time.Duration {
+	if config.MaxBackoff > 0 && d > config.MaxBackoff {
+		return config.MaxBackoff
+	}
+	return d
 }
 
+// DoWithNotify is a thin wrapper over Do/Hooks.OnRetry, kept for callers
+// already using the (error, time.Duration) notification signature. New code
+// should set Config.Hooks directly.
 func DoWithNotify(ctx context.Context, config Config, operation Retryable, notify func(error, time.Duration)) error {
-	var lastErr error
-	for attempt := 0; attempt < config.MaxRetries; attempt++ {
-		lastErr = operation()
-		if lastErr == nil {
-			return nil
+	if notify != nil {
+		config.Hooks.OnRetry = func(_ int, backoff time.Duration, err error) {
+			notify(err, backoff)
 		}
-
-		if !IsRetryable(lastErr) {
-			return fmt.Errorf("not retryable error: %w", lastErr)
-		}
-
-		if attempt == config.MaxRetries {
-			break
-		}
-		backoff := calculateBackoff(attempt, config)
-		if notify != nil {
-			notify(lastErr, backoff)
-		}
-
-		select {
-		case <-time.After(backoff):
-		case <-ctx.Done():
-			return fmt.Errorf("timed out: %w", lastErr)
-		}
-
 	}
 
-	return fmt.Errorf("retries exceeded: %w", lastErr)
+	return Do(ctx, config, operation)
 }
 
 func IsRetryable(err error) bool {
@@ -138,6 +240,12 @@ func IsRetryable(err error) bool {
 		return true
 	}
 
+	// Errors carrying a server-provided backoff hint are retryable by definition
+	var retryAfterErr RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		return true
+	}
+
 	// Network errors are usually retryable
 	var netErr net.Error
 	if errors.As(err, &netErr) {
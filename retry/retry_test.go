@@ -94,6 +94,31 @@ func TestRetryWithContextCancel(t *testing.T) {
 
 }
 
+func TestRetryWithContextCancelWrapsCtxErr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	operation := func() error {
+		attempts++
+		if attempts == 1 {
+			cancel()
+		}
+		return RetryableError{errors.New("temporary failure")}
+	}
+
+	config := Config{
+		MaxRetries:     5,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		BackoffFactor:  2.0,
+		JitterFactor:   0.1,
+	}
+	err := Do(ctx, config, operation)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected give-up error to wrap context.Canceled, got %v", err)
+	}
+}
+
 func TestBackOffCalculation(t *testing.T) {
 	config := Config{
 		InitialBackoff: 100 * time.Millisecond,
@@ -120,3 +145,233 @@ func TestBackOffCalculation(t *testing.T) {
 		}
 	}
 }
+
+func TestNextBackoffHonorsRetryAfter(t *testing.T) {
+	config := Config{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		BackoffFactor:  2.0,
+		JitterFactor:   0,
+	}
+
+	strategy := resolveStrategy(config)
+
+	err := RetryAfterError{Err: errors.New("rate limited"), After: 1 * time.Second}
+	if backoff := nextBackoff(0, config, err, strategy, 0); backoff != 1*time.Second {
+		t.Fatalf("expected retry-after hint of 1s, got %v", backoff)
+	}
+
+	// The hint must still be clamped by MaxBackoff.
+	err = RetryAfterError{Err: errors.New("rate limited"), After: 10 * time.Second}
+	if backoff := nextBackoff(0, config, err, strategy, 0); backoff != config.MaxBackoff {
+		t.Fatalf("expected retry-after hint clamped to %v, got %v", config.MaxBackoff, backoff)
+	}
+}
+
+func TestLinearBackoff(t *testing.T) {
+	strategy := NewLinearBackoff(100 * time.Millisecond)
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{2, 300 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if backoff := strategy.Next(tt.attempt, 0); backoff != tt.expected {
+			t.Fatalf("attempt %d: expected backoff of %v, got %v", tt.attempt, tt.expected, backoff)
+		}
+	}
+}
+
+func TestConstantBackoff(t *testing.T) {
+	strategy := NewConstantBackoff(250 * time.Millisecond)
+
+	for attempt := 0; attempt < 5; attempt++ {
+		if backoff := strategy.Next(attempt, time.Second); backoff != 250*time.Millisecond {
+			t.Fatalf("attempt %d: expected constant backoff of 250ms, got %v", attempt, backoff)
+		}
+	}
+}
+
+func TestFullJitterBackoffRespectsCap(t *testing.T) {
+	strategy := NewFullJitterBackoff(100*time.Millisecond, 500*time.Millisecond)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		backoff := strategy.Next(attempt, 0)
+		if backoff < 0 || backoff > 500*time.Millisecond {
+			t.Fatalf("attempt %d: backoff %v out of bounds [0, 500ms]", attempt, backoff)
+		}
+	}
+}
+
+func TestDecorrelatedJitterBackoffRespectsCapAndBase(t *testing.T) {
+	strategy := NewDecorrelatedJitterBackoff(100*time.Millisecond, 1*time.Second)
+
+	lastBackoff := time.Duration(0)
+	for attempt := 0; attempt < 10; attempt++ {
+		backoff := strategy.Next(attempt, lastBackoff)
+		if backoff < 100*time.Millisecond || backoff > time.Second {
+			t.Fatalf("attempt %d: backoff %v out of bounds [100ms, 1s]", attempt, backoff)
+		}
+		lastBackoff = backoff
+	}
+}
+
+func TestRetryAfterErrorIsRetryable(t *testing.T) {
+	err := RetryAfterError{Err: errors.New("rate limited"), After: time.Second}
+	if !IsRetryable(err) {
+		t.Fatal("expected RetryAfterError to be retryable")
+	}
+}
+
+func TestRetryUnlimitedMaxRetries(t *testing.T) {
+	attempts := 0
+	operation := func() error {
+		attempts++
+		if attempts < 5 {
+			return RetryableError{errors.New("retryable error")}
+		}
+		return nil
+	}
+
+	config := Config{
+		MaxRetries:     0,
+		InitialBackoff: 1 * time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		BackoffFactor:  1.5,
+		JitterFactor:   0,
+	}
+
+	if err := Do(context.Background(), config, operation); err != nil {
+		t.Fatalf("expected success with unlimited retries, got error: %v", err)
+	}
+
+	if attempts != 5 {
+		t.Fatalf("expected 5 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryMaxElapsedTime(t *testing.T) {
+	operation := func() error {
+		return RetryableError{errors.New("always fails")}
+	}
+
+	config := Config{
+		MaxRetries:     0,
+		InitialBackoff: 20 * time.Millisecond,
+		MaxBackoff:     20 * time.Millisecond,
+		BackoffFactor:  1,
+		JitterFactor:   0,
+		MaxElapsedTime: 30 * time.Millisecond,
+	}
+
+	err := Do(context.Background(), config, operation)
+
+	if !errors.Is(err, ErrMaxElapsed) {
+		t.Fatalf("expected ErrMaxElapsed, got: %v", err)
+	}
+}
+
+func TestHooksAreCalled(t *testing.T) {
+	var attempts, retries int
+	var gaveUp bool
+	var succeeded bool
+
+	attemptCount := 0
+	operation := func() error {
+		attemptCount++
+		if attemptCount < 3 {
+			return RetryableError{errors.New("retryable error")}
+		}
+		return nil
+	}
+
+	config := Config{
+		MaxRetries:     3,
+		InitialBackoff: 1 * time.Millisecond,
+		MaxBackoff:     1 * time.Millisecond,
+		BackoffFactor:  1,
+		JitterFactor:   0,
+		Hooks: Hooks{
+			OnAttempt: func(int, error) { attempts++ },
+			OnRetry:   func(int, time.Duration, error) { retries++ },
+			OnGiveUp:  func(error) { gaveUp = true },
+			OnSuccess: func(int, time.Duration) { succeeded = true },
+		},
+	}
+
+	if err := Do(context.Background(), config, operation); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected OnAttempt called 3 times, got %d", attempts)
+	}
+	if retries != 2 {
+		t.Fatalf("expected OnRetry called 2 times, got %d", retries)
+	}
+	if gaveUp {
+		t.Fatal("expected OnGiveUp not to be called on success")
+	}
+	if !succeeded {
+		t.Fatal("expected OnSuccess to be called")
+	}
+}
+
+func TestClassifyFallsBackToDefaults(t *testing.T) {
+	config := Config{}
+	if classify(config, RetryableError{errors.New("boom")}) != true {
+		t.Fatal("expected RetryableError to remain retryable with no classifiers configured")
+	}
+}
+
+func TestClassifyHonorsClassifierOrder(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	config := Config{
+		Classifiers: []Classifier{
+			ClassifierFunc(func(error) Decision { return Unknown }),
+			ClassifierFunc(func(error) Decision { return DoNotRetry }),
+		},
+	}
+
+	// Even though RetryableError is retryable by default, a classifier
+	// earlier in the chain returning DoNotRetry must win.
+	if classify(config, RetryableError{errBoom}) != false {
+		t.Fatal("expected classifier decision to override the default")
+	}
+}
+
+func TestHTTPStatusClassifier(t *testing.T) {
+	classifier := HTTPStatusClassifier(429, 503)
+
+	retryable := HTTPStatusError{StatusCode: 503, Err: errors.New("unavailable")}
+	if classifier.Classify(retryable) != Retry {
+		t.Fatalf("expected 503 to be classified as Retry")
+	}
+
+	notRetryable := HTTPStatusError{StatusCode: 400, Err: errors.New("bad request")}
+	if classifier.Classify(notRetryable) != DoNotRetry {
+		t.Fatalf("expected 400 to be classified as DoNotRetry")
+	}
+
+	if classifier.Classify(errors.New("unrelated")) != Unknown {
+		t.Fatalf("expected a non-HTTPStatusError to be classified as Unknown")
+	}
+}
+
+func TestRegexpClassifier(t *testing.T) {
+	classifier := RegexpClassifier(`(?i)connection reset`)
+
+	if classifier.Classify(errors.New("read: connection reset by peer")) != Retry {
+		t.Fatal("expected matching error to be classified as Retry")
+	}
+
+	if classifier.Classify(errors.New("invalid input syntax")) != Unknown {
+		t.Fatal("expected non-matching error to be classified as Unknown")
+	}
+}
@@ -0,0 +1,33 @@
+// Package sqlretry classifies common Postgres transient failures for
+// retry.Config.Classifiers, so callers don't need to hand-roll string
+// matching against driver errors the way isTransientDBError used to.
+package sqlretry
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+// SQLClassifier retries Postgres errors whose SQLSTATE indicates a
+// transient failure: 40001 (serialization_failure), 40P01
+// (deadlock_detected), and the 08xxx connection_exception class. It has no
+// opinion on errors that aren't a *pq.Error.
+func SQLClassifier() retry.Classifier {
+	return retry.ClassifierFunc(func(err error) retry.Decision {
+		var pqErr *pq.Error
+		if !errors.As(err, &pqErr) {
+			return retry.Unknown
+		}
+
+		code := string(pqErr.Code)
+		if code == "40001" || code == "40P01" || strings.HasPrefix(code, "08") {
+			return retry.Retry
+		}
+
+		return retry.DoNotRetry
+	})
+}
@@ -0,0 +1,43 @@
+package sqlretry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/harikishoreadabala/go-retry-mechanism/retry"
+)
+
+func TestSQLClassifier(t *testing.T) {
+	classifier := SQLClassifier()
+
+	tests := []struct {
+		name     string
+		code     string
+		expected retry.Decision
+	}{
+		{"serialization_failure", "40001", retry.Retry},
+		{"deadlock_detected", "40P01", retry.Retry},
+		{"connection_exception", "08000", retry.Retry},
+		{"connection_does_not_exist", "08003", retry.Retry},
+		{"not_null_violation", "23502", retry.DoNotRetry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &pq.Error{Code: pq.ErrorCode(tt.code)}
+			if got := classifier.Classify(err); got != tt.expected {
+				t.Fatalf("code %s: expected %v, got %v", tt.code, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSQLClassifierIgnoresNonPQErrors(t *testing.T) {
+	classifier := SQLClassifier()
+
+	if got := classifier.Classify(errors.New("not a pq error")); got != retry.Unknown {
+		t.Fatalf("expected a non-*pq.Error to classify as Unknown, got %v", got)
+	}
+}